@@ -0,0 +1,100 @@
+package addon
+
+import (
+	"github.com/konveyor/controller/pkg/logging"
+)
+
+//
+// Log is the addon SDK's logger.
+var Log = logging.WithName("addon")
+
+//
+// SoftError is returned by an addon adapter to report a handled,
+// non-fatal failure. Run logs it as a task failure with the given
+// Reason rather than as a fatal error.
+type SoftError struct {
+	Reason string
+}
+
+func (e *SoftError) Error() string {
+	return e.Reason
+}
+
+//
+// Addon provides the integration used by addon adapters: task
+// lifecycle, the hub REST API, and structured progress reporting.
+type Addon struct {
+	// Task being executed.
+	Task TaskClient
+	// Application API.
+	Application ApplicationClient
+	// TagType API.
+	TagType TagTypeClient
+	// Tag API.
+	Tag TagClient
+	// progress reporting, pushed to Task.
+	progress *Progress
+}
+
+//
+// Addon is the package singleton used by addon adapters.
+var Addon = newAddon()
+
+func newAddon() *Addon {
+	a := &Addon{}
+	a.progress = NewProgress(&a.Task)
+	return a
+}
+
+//
+// Run fn, reporting a returned *SoftError as a task failure and any
+// other error as fatal.
+func (a *Addon) Run(fn func() error) {
+	err := fn()
+	switch e := err.(type) {
+	case nil:
+	case *SoftError:
+		Log.Info("Task failed.", "reason", e.Reason)
+	default:
+		Log.Error(e, "Task failed (fatal).")
+	}
+}
+
+//
+// DataWith fetches the task's Data, validates it against the
+// registered Manifest's Schema and unmarshals it into d.
+func (a *Addon) DataWith(d interface{}) (err error) {
+	data, err := a.Task.Data()
+	if err != nil {
+		return
+	}
+	err = DataWith(d, data)
+	return
+}
+
+//
+// Progress returns the Addon's structured progress reporter.
+func (a *Addon) Progress() *Progress {
+	return a.progress
+}
+
+//
+// Total sets the total unit count.
+// Deprecated: use Addon.Progress().Begin() instead.
+func (a *Addon) Total(n int) {
+	a.progress.Begin(n, "items")
+}
+
+//
+// Increment the completed count by 1.
+// Deprecated: use Addon.Progress().Add() instead.
+func (a *Addon) Increment() {
+	a.progress.Add(1)
+}
+
+//
+// Activity sets the current activity/stage description.
+// Deprecated: use Addon.Progress().Stage() instead.
+func (a *Addon) Activity(format string, v ...interface{}) {
+	a.progress.Stage(format, v...)
+}
@@ -0,0 +1,22 @@
+package addon
+
+import (
+	"fmt"
+
+	"github.com/konveyor/tackle2-hub/api"
+)
+
+//
+// ApplicationClient provides access to the Application API.
+type ApplicationClient struct{}
+
+//
+// Update the application.
+func (r *ApplicationClient) Update(application *api.Application) (err error) {
+	err = request(
+		"PUT",
+		fmt.Sprintf("/applications/%d", application.ID),
+		application,
+		nil)
+	return
+}
@@ -0,0 +1,205 @@
+package addon
+
+import (
+	"html/template"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//
+// Bucket represents a directory (file-storage bucket) whose contents
+// can be published as a browsable static index.
+type Bucket struct {
+	// Path is the bucket's root directory on disk.
+	Path string
+}
+
+//
+// IndexOptions controls PublishIndex's generated index.
+type IndexOptions struct {
+	// Template overrides the built-in index template.
+	Template *template.Template
+	// Title is shown at the top of the index page.
+	Title string
+}
+
+//
+// indexEntry is a row rendered in the generated index.
+type indexEntry struct {
+	Name    string
+	Href    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+	Icon    string
+}
+
+//
+// breadcrumb is one link in the path back to the published root.
+type breadcrumb struct {
+	Name string
+	Href string
+}
+
+//
+// indexPage is the data passed to the index Template.
+type indexPage struct {
+	Title       string
+	Breadcrumbs []breadcrumb
+	Entries     []indexEntry
+}
+
+//
+// defaultIndexTemplate renders a sortable, breadcrumbed HTML index.
+// html/template auto-escapes Name and Href, closing the
+// XSS-on-filename hole the old string-concatenation index had.
+var defaultIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 4px 12px; }
+th { cursor: pointer; border-bottom: 1px solid #ccc; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav>
+<a href="..">..</a>
+{{range .Breadcrumbs}} / <a href="{{.Href}}">{{.Name}}</a>{{end}}
+</nav>
+<table id="index">
+<thead><tr><th data-col="name">Name</th><th data-col="size">Size</th><th data-col="mtime">Modified</th></tr></thead>
+<tbody>
+{{range .Entries}}<tr><td>{{.Icon}} <a href="{{.Href}}">{{.Name}}</a></td><td>{{.Size}}</td><td>{{.ModTime}}</td></tr>
+{{end}}</tbody>
+</table>
+<script>
+document.querySelectorAll('#index th').forEach(function(th, i) {
+  th.addEventListener('click', function() {
+    var rows = Array.from(document.querySelectorAll('#index tbody tr'));
+    rows.sort(function(a, b) {
+      return a.children[i].innerText.localeCompare(b.children[i].innerText);
+    });
+    rows.forEach(function(r) { r.parentNode.appendChild(r); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+//
+// PublishIndex generates index.html files for dir and, recursively,
+// every subdirectory beneath it, each listing its own entries with
+// size, modification time, a mime-type icon, and breadcrumb
+// navigation back to dir.
+func (b *Bucket) PublishIndex(dir string, opts IndexOptions) (err error) {
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultIndexTemplate
+	}
+	title := opts.Title
+	if title == "" {
+		title = filepath.Base(dir)
+	}
+	return b.publishIndex(dir, dir, title, tmpl)
+}
+
+//
+// publishIndex writes dir's index.html, relative to root, then
+// recurses into its subdirectories.
+func (b *Bucket) publishIndex(root, dir, title string, tmpl *template.Template) (err error) {
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	page := indexPage{
+		Title:       title,
+		Breadcrumbs: breadcrumbs(root, dir),
+	}
+	for _, d := range list {
+		if d.Name() == "index.html" {
+			continue
+		}
+		var info os.FileInfo
+		info, err = d.Info()
+		if err != nil {
+			return
+		}
+		page.Entries = append(page.Entries, indexEntry{
+			Name:    d.Name(),
+			Href:    d.Name(),
+			IsDir:   d.IsDir(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			Icon:    icon(d.Name(), d.IsDir()),
+		})
+		if d.IsDir() {
+			err = b.publishIndex(root, filepath.Join(dir, d.Name()), title, tmpl)
+			if err != nil {
+				return
+			}
+		}
+	}
+	sort.Slice(page.Entries, func(i, j int) bool {
+		return page.Entries[i].Name < page.Entries[j].Name
+	})
+
+	var f *os.File
+	f, err = os.Create(filepath.Join(dir, "index.html"))
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	err = tmpl.Execute(f, page)
+	return
+}
+
+//
+// breadcrumbs builds the link trail from root down to dir, with each
+// crumb's Href relative to dir (the page it is rendered into) rather
+// than root, so a crumb for an ancestor N levels up resolves to
+// "../" repeated N times instead of a root-relative path that only
+// happens to work one level deep.
+func breadcrumbs(root, dir string) (crumbs []breadcrumb) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i, part := range parts {
+		hops := len(parts) - 1 - i
+		href := strings.Repeat("../", hops)
+		if href == "" {
+			href = "."
+		}
+		crumbs = append(crumbs, breadcrumb{Name: part, Href: href})
+	}
+	return
+}
+
+//
+// icon returns a small glyph representing name's mime type.
+func icon(name string, isDir bool) string {
+	if isDir {
+		return "\U0001F4C1"
+	}
+	t := mime.TypeByExtension(filepath.Ext(name))
+	switch {
+	case strings.HasPrefix(t, "image/"):
+		return "\U0001F5BC"
+	case strings.HasPrefix(t, "text/"):
+		return "\U0001F4C4"
+	default:
+		return "\U0001F4C4"
+	}
+}
@@ -0,0 +1,52 @@
+package addon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+//
+// baseURL and token configure the REST client used to talk to the hub
+// API; both are set in the environment of the addon container.
+var (
+	baseURL = os.Getenv("HUB_BASE_URL")
+	token   = os.Getenv("TOKEN")
+)
+
+//
+// request performs method against path on the hub API, encoding in as
+// the JSON request body (when not nil) and decoding the JSON response
+// body into out (when not nil).
+func request(method, path string, in, out interface{}) (err error) {
+	body := &bytes.Buffer{}
+	if in != nil {
+		err = json.NewEncoder(body).Encode(in)
+		if err != nil {
+			return
+		}
+	}
+	req, err := http.NewRequest(method, baseURL+path, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	reply, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = reply.Body.Close()
+	}()
+	if reply.StatusCode >= http.StatusMultipleChoices {
+		err = fmt.Errorf("addon: %s %s: %s", method, path, reply.Status)
+		return
+	}
+	if out != nil {
+		err = json.NewDecoder(reply.Body).Decode(out)
+	}
+	return
+}
@@ -0,0 +1,216 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+//
+// Field describes one property of an addon's Data schema: its type,
+// whether it is required, a default value, and a human description
+// the hub UI can render as a form label.
+type Field struct {
+	Name        string
+	Type        string
+	Required    bool
+	Default     string
+	Description string
+}
+
+//
+// Schema is the set of Fields an addon's Data must satisfy.
+type Schema []Field
+
+//
+// ValidationError reports the Data fields that failed Schema
+// validation: Missing lists required fields with no value, Invalid
+// lists fields whose value did not match the declared Type.
+type ValidationError struct {
+	Missing []string
+	Invalid []string
+}
+
+func (e *ValidationError) Error() string {
+	parts := []string{}
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, "invalid: "+strings.Join(e.Invalid, ", "))
+	}
+	return "addon: data validation failed (" + strings.Join(parts, "; ") + ")"
+}
+
+//
+// Manifest declares an addon's Data Schema so the hub can render a
+// form for it and validate task input before the task is submitted.
+type Manifest struct {
+	Schema Schema
+}
+
+//
+// manifest is the package singleton populated by Register.
+var manifest = &Manifest{}
+
+//
+// Register declares the addon's Data Schema at startup. Call it from
+// the addon's main before addon.Run.
+func Register(schema Schema) {
+	manifest.Schema = schema
+}
+
+//
+// ManifestHandler serves the registered Manifest's Schema as JSON. The
+// hub mounts this at a task-kind-scoped route (e.g.
+// /task-kinds/{id}/manifest) so the UI can fetch it to render a form
+// and validate inputs before a task is submitted.
+func ManifestHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(manifest.Schema)
+}
+
+//
+// SchemaFor derives a Schema from a Go struct by inspecting its
+// exported fields. The `json` tag supplies the field name, a
+// `required:"true"` tag marks it mandatory, `default` supplies a
+// default value, and `desc` is the human description.
+func SchemaFor(v interface{}) (schema Schema) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported.
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			name = strings.Split(tag, ",")[0]
+		}
+		schema = append(schema, Field{
+			Name:        name,
+			Type:        kindName(f.Type),
+			Required:    f.Tag.Get("required") == "true",
+			Default:     f.Tag.Get("default"),
+			Description: f.Tag.Get("desc"),
+		})
+	}
+	return
+}
+
+//
+// kindName maps a Go type to the schema's field Type name.
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+//
+// jsonKind reports the schema Type name of a value produced by
+// unmarshaling JSON into an interface{} (json.Number is not used, so
+// every JSON number decodes as float64).
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+//
+// matchesType reports whether a decoded JSON value's kind satisfies a
+// Field's declared Type; "int" accepts any JSON number, since JSON
+// itself has no separate integer type.
+func matchesType(v interface{}, want string) bool {
+	got := jsonKind(v)
+	if want == "int" && got == "number" {
+		return true
+	}
+	return got == want
+}
+
+//
+// validate checks the raw, submitted JSON data against the registered
+// Manifest's Schema, collecting missing required fields and fields
+// whose submitted value does not match the declared Type. It inspects
+// the JSON itself - not the destination Go struct - so a value whose
+// shape doesn't match its Field (e.g. a string where a number was
+// declared) is actually caught, instead of always matching the
+// struct's own, already-correct field type.
+func validate(data []byte) (err error) {
+	if len(manifest.Schema) == 0 {
+		return
+	}
+	raw := map[string]interface{}{}
+	if len(data) > 0 {
+		jErr := json.Unmarshal(data, &raw)
+		if jErr != nil {
+			err = fmt.Errorf("addon: failed to unmarshal data: %w", jErr)
+			return
+		}
+	}
+	verr := &ValidationError{}
+	for _, field := range manifest.Schema {
+		v, found := raw[field.Name]
+		if !found || v == nil {
+			if field.Required {
+				verr.Missing = append(verr.Missing, field.Name)
+			}
+			continue
+		}
+		if !matchesType(v, field.Type) {
+			verr.Invalid = append(verr.Invalid, field.Name)
+		}
+	}
+	if len(verr.Missing) > 0 || len(verr.Invalid) > 0 {
+		err = verr
+	}
+	return
+}
+
+//
+// DataWith validates the task's raw Data against the registered
+// Manifest's Schema, returning a *ValidationError listing any missing
+// or invalid fields, then unmarshals it into d.
+func DataWith(d interface{}, data []byte) (err error) {
+	err = validate(data)
+	if err != nil {
+		return
+	}
+	if len(data) > 0 {
+		err = json.Unmarshal(data, d)
+		if err != nil {
+			err = fmt.Errorf("addon: failed to unmarshal data: %w", err)
+		}
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package addon
+
+import "testing"
+
+type testData struct {
+	Name string `json:"name" required:"true"`
+	Port int    `json:"port"`
+}
+
+func TestValidateDetectsMissingRequiredField(t *testing.T) {
+	Register(SchemaFor(testData{}))
+	defer Register(nil)
+
+	err := validate([]byte(`{"port":8080}`))
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Missing) != 1 || verr.Missing[0] != "name" {
+		t.Fatalf("expected missing [name], got %v", verr.Missing)
+	}
+}
+
+func TestValidateDetectsInvalidType(t *testing.T) {
+	Register(SchemaFor(testData{}))
+	defer Register(nil)
+
+	err := validate([]byte(`{"name":"x","port":"not-a-number"}`))
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Invalid) != 1 || verr.Invalid[0] != "port" {
+		t.Fatalf("expected invalid [port], got %v", verr.Invalid)
+	}
+}
+
+func TestValidatePassesValidData(t *testing.T) {
+	Register(SchemaFor(testData{}))
+	defer Register(nil)
+
+	err := validate([]byte(`{"name":"x","port":8080}`))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestSchemaForDerivesNameFromJSONTag(t *testing.T) {
+	schema := SchemaFor(testData{})
+	byName := map[string]Field{}
+	for _, f := range schema {
+		byName[f.Name] = f
+	}
+	name, ok := byName["name"]
+	if !ok || !name.Required {
+		t.Fatalf("expected a required %q field, got %v", "name", schema)
+	}
+	port, ok := byName["port"]
+	if !ok || port.Type != "int" {
+		t.Fatalf("expected an int %q field, got %v", "port", schema)
+	}
+}
@@ -0,0 +1,150 @@
+package addon
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//
+// pushInterval is the minimum time between pushed Reports; Add and
+// Stage calls within the window are coalesced into the next push so a
+// tight per-item loop doesn't spam the hub with one call per item.
+const pushInterval = time.Second
+
+//
+// smoothing is the weight given to the current sample when updating
+// the moving-average throughput; lower values smooth out bursts.
+const smoothing = 0.3
+
+//
+// Report is a structured progress update pushed to a Reporter.
+type Report struct {
+	// Stage is the current activity/phase description.
+	Stage string
+	// Completed units of work.
+	Completed int
+	// Total units of work.
+	Total int
+	// Unit is the name of the thing being counted (e.g. "files").
+	Unit string
+	// Rate is the moving-average throughput in units/sec.
+	Rate float64
+	// ETA is the estimated time remaining, based on Rate.
+	ETA time.Duration
+}
+
+//
+// Reporter is implemented by the task client that Progress pushes
+// structured updates to (the hub Task's progress API).
+type Reporter interface {
+	Update(Report) error
+}
+
+//
+// Progress tracks completion of a unit of work and pushes throttled,
+// structured updates - completed, total, rate, ETA, and the current
+// stage - to a Reporter. It coalesces bursts of Add() calls instead of
+// issuing one push per call.
+type Progress struct {
+	mu        sync.Mutex
+	reporter  Reporter
+	stage     string
+	total     int
+	unit      string
+	completed int
+	rate      float64
+	started   time.Time
+	lastPush  time.Time
+}
+
+//
+// NewProgress builds a Progress that pushes updates to the reporter.
+func NewProgress(reporter Reporter) *Progress {
+	return &Progress{reporter: reporter}
+}
+
+//
+// Begin starts tracking a unit of work with the given total and unit name.
+func (p *Progress) Begin(total int, unit string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+	p.unit = unit
+	p.completed = 0
+	p.rate = 0
+	p.started = time.Now()
+	p.lastPush = time.Time{}
+	p.push(true)
+}
+
+//
+// Add n completed units to the total.
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed += n
+	p.updateRate()
+	p.push(false)
+}
+
+//
+// Stage sets the current activity/stage description.
+func (p *Progress) Stage(format string, v ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stage = fmt.Sprintf(format, v...)
+	p.push(false)
+}
+
+//
+// End reports the final, completed state.
+func (p *Progress) End() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.completed < p.total {
+		p.completed = p.total
+	}
+	p.push(true)
+}
+
+//
+// updateRate recomputes the moving-average throughput in units/sec.
+// Must be called with mu held.
+func (p *Progress) updateRate() {
+	elapsed := time.Since(p.started).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	current := float64(p.completed) / elapsed
+	if p.rate == 0 {
+		p.rate = current
+		return
+	}
+	p.rate = smoothing*current + (1-smoothing)*p.rate
+}
+
+//
+// push sends a Report to the reporter, skipping intermediate updates
+// within pushInterval unless forced. Must be called with mu held.
+func (p *Progress) push(force bool) {
+	if p.reporter == nil {
+		return
+	}
+	if !force && time.Since(p.lastPush) < pushInterval {
+		return
+	}
+	p.lastPush = time.Now()
+	var eta time.Duration
+	if remaining := p.total - p.completed; p.rate > 0 && remaining > 0 {
+		eta = time.Duration(float64(remaining)/p.rate*float64(time.Second))
+	}
+	_ = p.reporter.Update(Report{
+		Stage:     p.stage,
+		Completed: p.completed,
+		Total:     p.total,
+		Unit:      p.unit,
+		Rate:      p.rate,
+		ETA:       eta,
+	})
+}
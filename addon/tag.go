@@ -0,0 +1,39 @@
+package addon
+
+import "github.com/konveyor/tackle2-hub/api"
+
+//
+// TagTypeClient provides access to the TagType API.
+type TagTypeClient struct{}
+
+//
+// Create a TagType.
+func (r *TagTypeClient) Create(tt *api.TagType) (err error) {
+	err = request("POST", "/tagtypes", tt, tt)
+	return
+}
+
+//
+// List TagTypes.
+func (r *TagTypeClient) List() (list []api.TagType, err error) {
+	err = request("GET", "/tagtypes", nil, &list)
+	return
+}
+
+//
+// TagClient provides access to the Tag API.
+type TagClient struct{}
+
+//
+// Create a Tag.
+func (r *TagClient) Create(tag *api.Tag) (err error) {
+	err = request("POST", "/tags", tag, tag)
+	return
+}
+
+//
+// List Tags.
+func (r *TagClient) List() (list []api.Tag, err error) {
+	err = request("GET", "/tags", nil, &list)
+	return
+}
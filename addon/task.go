@@ -0,0 +1,64 @@
+package addon
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/konveyor/tackle2-hub/api"
+)
+
+//
+// TaskClient provides access to the Task being executed by the addon,
+// and implements Reporter so a Progress can push structured updates
+// to it.
+type TaskClient struct{}
+
+//
+// ID of the task being executed, from the TASK environment variable
+// the hub sets when it launches the addon container.
+func (r *TaskClient) ID() (id uint) {
+	n, _ := strconv.Atoi(os.Getenv("TASK"))
+	id = uint(n)
+	return
+}
+
+//
+// Application returns the application associated with the task.
+func (r *TaskClient) Application() (application *api.Application, err error) {
+	application = &api.Application{}
+	err = request(
+		"GET",
+		fmt.Sprintf("/tasks/%d/application", r.ID()),
+		nil,
+		application)
+	return
+}
+
+//
+// Data returns the task's raw Data payload.
+func (r *TaskClient) Data() (data []byte, err error) {
+	task := &api.Task{}
+	err = request(
+		"GET",
+		fmt.Sprintf("/tasks/%d", r.ID()),
+		nil,
+		task)
+	if err != nil {
+		return
+	}
+	data = task.Data
+	return
+}
+
+//
+// Update pushes a structured progress Report to the task. Update
+// implements Reporter.
+func (r *TaskClient) Update(report Report) (err error) {
+	err = request(
+		"PUT",
+		fmt.Sprintf("/tasks/%d/report", r.ID()),
+		report,
+		nil)
+	return
+}
@@ -0,0 +1,162 @@
+//
+// Package walk provides a reusable, testable filesystem listing
+// primitive for addons, replacing ad-hoc shell-outs to the `find`
+// binary (which isn't portable and fails on non-Linux base images).
+package walk
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/konveyor/tackle2-hub/addon"
+)
+
+//
+// Entry describes one filesystem object found during a Walk.
+type Entry struct {
+	// Path relative to the walked root.
+	Path string
+	// Name is the base name of the entry.
+	Name string
+	// Size in bytes. Zero for directories.
+	Size int64
+	// Mode is the entry's file mode.
+	Mode fs.FileMode
+	// ModTime is the entry's last-modified time.
+	ModTime time.Time
+	// IsDir reports whether the entry is a directory.
+	IsDir bool
+}
+
+//
+// Options controls Walk's traversal and filtering.
+type Options struct {
+	// MaxDepth limits recursion; 0 means unlimited.
+	MaxDepth int
+	// FollowSymlinks lists symlinked entries; a symlinked directory is
+	// listed but not descended into. Default is false: symlinks are
+	// skipped.
+	FollowSymlinks bool
+	// Include, when set, keeps only files whose base name matches one
+	// of these glob patterns (path.Match syntax).
+	Include []string
+	// Exclude drops files whose base name matches one of these glob
+	// patterns, applied after Include.
+	Exclude []string
+	// ReadableOnly drops files that cannot be opened for reading.
+	ReadableOnly bool
+	// MaxSize drops files larger than this many bytes; 0 means unlimited.
+	MaxSize int64
+	// FS is the backend to walk, letting addons list a tar/zip archive
+	// or an in-memory bucket instead of the OS filesystem. Defaults to
+	// an os.DirFS rooted at the walked root.
+	FS fs.FS
+	// Progress, when set, receives an event per listed entry.
+	Progress *addon.Progress
+}
+
+//
+// Walk lists the entries under root, applying opts. Entries are
+// returned in the order they are visited (depth-first, as yielded by
+// fs.WalkDir).
+func Walk(root string, opts Options) (entries []Entry, err error) {
+	fsys := opts.FS
+	if fsys == nil {
+		fsys = os.DirFS(root)
+	}
+	if opts.Progress != nil {
+		opts.Progress.Begin(0, "entries")
+		defer opts.Progress.End()
+	}
+	err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, wErr error) error {
+		if wErr != nil {
+			return wErr
+		}
+		if p == "." {
+			return nil
+		}
+		depth := strings.Count(p, "/") + 1
+		if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !opts.FollowSymlinks && d.Type()&fs.ModeSymlink != 0 {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && !included(d.Name(), opts) {
+			return nil
+		}
+		info, iErr := d.Info()
+		if iErr != nil {
+			return iErr
+		}
+		if !d.IsDir() {
+			if opts.MaxSize > 0 && info.Size() > opts.MaxSize {
+				return nil
+			}
+			if opts.ReadableOnly && !readable(fsys, p) {
+				return nil
+			}
+		}
+		var size int64
+		if !d.IsDir() {
+			size = info.Size()
+		}
+		entries = append(entries, Entry{
+			Path:    p,
+			Name:    d.Name(),
+			Size:    size,
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+			IsDir:   d.IsDir(),
+		})
+		if opts.Progress != nil {
+			opts.Progress.Stage("%s", p)
+			opts.Progress.Add(1)
+		}
+		return nil
+	})
+	return
+}
+
+//
+// included reports whether name passes the Include/Exclude globs.
+func included(name string, opts Options) bool {
+	if len(opts.Include) > 0 {
+		matched := false
+		for _, pattern := range opts.Include {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, pattern := range opts.Exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+	return true
+}
+
+//
+// readable reports whether p can be opened for reading on fsys.
+func readable(fsys fs.FS, p string) bool {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
@@ -0,0 +1,117 @@
+package walk
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func paths(entries []Entry) (out []string) {
+	for _, e := range entries {
+		out = append(out, e.Path)
+	}
+	return
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWalkMaxDepth(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":         &fstest.MapFile{Data: []byte("a")},
+		"dir/b.txt":     &fstest.MapFile{Data: []byte("b")},
+		"dir/sub/c.txt": &fstest.MapFile{Data: []byte("c")},
+	}
+	entries, err := Walk(".", Options{FS: fsys, MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	got := paths(entries)
+	if !contains(got, "a.txt") {
+		t.Fatalf("expected a.txt in %v", got)
+	}
+	if contains(got, "dir/b.txt") {
+		t.Fatalf("expected dir/b.txt to be excluded by MaxDepth, got %v", got)
+	}
+	if contains(got, "dir/sub/c.txt") {
+		t.Fatalf("expected dir/sub/c.txt to be excluded by MaxDepth, got %v", got)
+	}
+}
+
+func TestWalkInclude(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": &fstest.MapFile{Data: []byte("a")},
+		"a.log": &fstest.MapFile{Data: []byte("a")},
+	}
+	entries, err := Walk(".", Options{FS: fsys, Include: []string{"*.txt"}})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	got := paths(entries)
+	if !contains(got, "a.txt") {
+		t.Fatalf("expected a.txt in %v", got)
+	}
+	if contains(got, "a.log") {
+		t.Fatalf("expected a.log to be excluded by Include, got %v", got)
+	}
+}
+
+func TestWalkExclude(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": &fstest.MapFile{Data: []byte("a")},
+		"a.log": &fstest.MapFile{Data: []byte("a")},
+	}
+	entries, err := Walk(".", Options{FS: fsys, Exclude: []string{"*.log"}})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	got := paths(entries)
+	if !contains(got, "a.txt") {
+		t.Fatalf("expected a.txt in %v", got)
+	}
+	if contains(got, "a.log") {
+		t.Fatalf("expected a.log to be excluded, got %v", got)
+	}
+}
+
+func TestWalkMaxSize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"small.txt": &fstest.MapFile{Data: []byte("x")},
+		"big.txt":   &fstest.MapFile{Data: make([]byte, 100)},
+	}
+	entries, err := Walk(".", Options{FS: fsys, MaxSize: 10})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	got := paths(entries)
+	if !contains(got, "small.txt") {
+		t.Fatalf("expected small.txt in %v", got)
+	}
+	if contains(got, "big.txt") {
+		t.Fatalf("expected big.txt to be excluded by MaxSize, got %v", got)
+	}
+}
+
+func TestWalkSkipsSymlinksByDefault(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":    &fstest.MapFile{Data: []byte("a")},
+		"link.txt": &fstest.MapFile{Data: []byte("a.txt"), Mode: fs.ModeSymlink | 0777},
+	}
+	entries, err := Walk(".", Options{FS: fsys})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	got := paths(entries)
+	if !contains(got, "a.txt") {
+		t.Fatalf("expected a.txt in %v", got)
+	}
+	if contains(got, "link.txt") {
+		t.Fatalf("expected link.txt to be skipped by default, got %v", got)
+	}
+}
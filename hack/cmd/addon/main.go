@@ -7,14 +7,12 @@ deliberately minimized to reduce code clutter.
 package main
 
 import (
-	"bytes"
 	"errors"
 	hub "github.com/konveyor/tackle2-hub/addon"
+	"github.com/konveyor/tackle2-hub/addon/walk"
 	"github.com/konveyor/tackle2-hub/api"
 	"os"
-	"os/exec"
 	pathlib "path"
-	"strings"
 	"time"
 )
 
@@ -29,11 +27,12 @@ type SoftError = hub.SoftError
 //
 // main
 func main() {
+	hub.Register(hub.SchemaFor(Data{}))
 	addon.Run(func() (err error) {
 		//
 		// Get the addon data associated with the task.
 		d := &Data{}
-		_ = addon.DataWith(d)
+		err = addon.DataWith(d)
 		if err != nil {
 			return
 		}
@@ -45,7 +44,7 @@ func main() {
 		}
 		//
 		// Find files.
-		paths, _ := find(d.Path, 25)
+		paths, _ := list(d.Path, 25)
 		//
 		// List directory.
 		err = listDir(d, application, paths)
@@ -117,7 +116,8 @@ func listDir(d *Data, application *api.Application, paths []string) (err error)
 	}
 	//
 	// Build the index.
-	err = buildIndex(output)
+	bucket := &hub.Bucket{Path: application.Bucket}
+	err = bucket.PublishIndex(output, hub.IndexOptions{Title: "Listed files"})
 	if err != nil {
 		return
 	}
@@ -128,62 +128,24 @@ func listDir(d *Data, application *api.Application, paths []string) (err error)
 }
 
 //
-// Build index.html
-func buildIndex(output string) (err error) {
-	addon.Activity("Building index.")
-	time.Sleep(time.Second)
-	dir := output
-	path := pathlib.Join(dir, "index.html")
-	f, err := os.Create(path)
-	if err != nil {
-		return
-	}
-	defer func() {
-		_ = f.Close()
-	}()
-	body := []string{"<ul>"}
-	list, err := os.ReadDir(dir)
-	if err != nil {
-		return
-	}
-	for _, name := range list {
-		body = append(
-			body,
-			"<li><a href=\""+name.Name()+"\">"+name.Name()+"</a>")
-	}
-
-	body = append(body, "</ul>")
-
-	_, _ = f.WriteString(strings.Join(body, "\n"))
-
-	return
-}
-
-//
-// find files.
-func find(path string, max int) (paths []string, err error) {
+// list the readable files directly under path, up to max.
+func list(path string, max int) (paths []string, err error) {
 	Log.Info("Listing.", "path", path)
-	cmd := exec.Command(
-		"find",
-		path,
-		"-maxdepth",
-		"1",
-		"-type",
-		"f",
-		"-readable")
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err = cmd.Run()
+	entries, err := walk.Walk(path, walk.Options{
+		MaxDepth:     1,
+		ReadableOnly: true,
+	})
 	if err != nil {
-		Log.Info(stderr.String())
 		return
 	}
-
-	paths = strings.Fields(stdout.String())
-	if len(paths) > max {
-		paths = paths[:max]
+	for _, e := range entries {
+		if e.IsDir {
+			continue
+		}
+		paths = append(paths, pathlib.Join(path, e.Path))
+		if len(paths) >= max {
+			break
+		}
 	}
 
 	Log.Info("List found.", "paths", paths)
@@ -314,5 +276,5 @@ func appTags(application *api.Application) (m map[string]uint) {
 // Data Addon input.
 type Data struct {
 	// Path to be listed.
-	Path string `json:"path"`
+	Path string `json:"path" required:"true" desc:"Directory to be listed."`
 }
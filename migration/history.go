@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	liberr "github.com/konveyor/controller/pkg/error"
+	"gorm.io/gorm"
+)
+
+//
+// MigrationHistory records the fingerprint of an applied Migration so
+// drift (an edited or reordered migration) can be detected on a later
+// startup.
+type MigrationHistory struct {
+	Version   int `gorm:"primaryKey"`
+	Name      string
+	Hash      string
+	AppliedAt time.Time
+	Duration  time.Duration
+}
+
+func (MigrationHistory) TableName() string {
+	return "migration_history"
+}
+
+//
+// fingerprint renders a Migration's Fingerprint() as a hex-encoded
+// SHA-256 digest suitable for storage and comparison.
+func fingerprint(m Migration) string {
+	sum := sha256.Sum256(m.Fingerprint())
+	return hex.EncodeToString(sum[:])
+}
+
+//
+// checkDrift verifies that every already-applied migration (versions
+// 1..version) still matches its recorded fingerprint. A migration
+// applied before migration_history existed has no record; it is
+// backfilled rather than treated as drift.
+func checkDrift(db *gorm.DB, migrations []Migration, version int) (err error) {
+	for i := 1; i <= version; i++ {
+		m := migrations[i]
+		hash := fingerprint(m)
+
+		history := &MigrationHistory{}
+		result := db.Where("version", i).First(history)
+		switch {
+		case errors.Is(result.Error, gorm.ErrRecordNotFound):
+			err = recordHistory(db, i, m, hash, 0)
+			if err != nil {
+				return
+			}
+		case result.Error != nil:
+			err = liberr.Wrap(result.Error)
+			return
+		case history.Hash != hash:
+			err = liberr.Wrap(
+				errors.New("migration: fingerprint drift detected, migration was edited or reordered"),
+				"version", m.Name())
+			return
+		}
+	}
+
+	return
+}
+
+//
+// recordHistory inserts the migration_history row for a version. There
+// is never an existing row to update here - callers only record a
+// version once, on first apply or backfill - so this is a plain
+// Create, not a Save (which would route to an UPDATE on this
+// non-zero, explicitly-set primary key and silently affect 0 rows).
+func recordHistory(db *gorm.DB, version int, m Migration, hash string, duration time.Duration) (err error) {
+	history := &MigrationHistory{
+		Version:   version,
+		Name:      m.Name(),
+		Hash:      hash,
+		AppliedAt: time.Now(),
+		Duration:  duration,
+	}
+	result := db.Create(history)
+	if result.Error != nil {
+		err = liberr.Wrap(result.Error)
+		return
+	}
+	return
+}
+
+//
+// deleteHistory removes the migration_history row for a reverted version.
+func deleteHistory(db *gorm.DB, version int) (err error) {
+	result := db.Where("version", version).Delete(&MigrationHistory{})
+	if result.Error != nil {
+		err = liberr.Wrap(result.Error)
+		return
+	}
+	return
+}
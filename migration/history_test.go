@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+//
+// fakeMigration is a Migration stand-in for exercising history.go
+// without a real schema change.
+type fakeMigration struct {
+	name string
+	hash []byte
+}
+
+func (m *fakeMigration) Apply(db *gorm.DB) error { return nil }
+func (m *fakeMigration) Name() string            { return m.name }
+func (m *fakeMigration) Fingerprint() []byte      { return m.hash }
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	err = db.AutoMigrate(&MigrationHistory{})
+	if err != nil {
+		t.Fatalf("failed to migrate MigrationHistory: %v", err)
+	}
+	return db
+}
+
+func TestRecordHistoryInsertsRow(t *testing.T) {
+	db := openTestDB(t)
+	m := &fakeMigration{name: "m1", hash: []byte("abc")}
+
+	err := recordHistory(db, 1, m, fingerprint(m), 0)
+	if err != nil {
+		t.Fatalf("recordHistory failed: %v", err)
+	}
+
+	history := &MigrationHistory{}
+	result := db.Where("version", 1).First(history)
+	if result.Error != nil {
+		t.Fatalf("expected history row, got error: %v", result.Error)
+	}
+	if history.Name != "m1" {
+		t.Fatalf("unexpected name: %s", history.Name)
+	}
+}
+
+func TestCheckDriftBackfillsMissingHistory(t *testing.T) {
+	db := openTestDB(t)
+	m := &fakeMigration{name: "m1", hash: []byte("abc")}
+	migrations := []Migration{nil, m}
+
+	err := checkDrift(db, migrations, 1)
+	if err != nil {
+		t.Fatalf("checkDrift failed: %v", err)
+	}
+
+	history := &MigrationHistory{}
+	result := db.Where("version", 1).First(history)
+	if result.Error != nil {
+		t.Fatalf("expected backfilled history row, got error: %v", result.Error)
+	}
+	if history.Hash != fingerprint(m) {
+		t.Fatalf("unexpected hash recorded: %s", history.Hash)
+	}
+}
+
+func TestCheckDriftDetectsEditedMigration(t *testing.T) {
+	db := openTestDB(t)
+	original := &fakeMigration{name: "m1", hash: []byte("abc")}
+
+	err := checkDrift(db, []Migration{nil, original}, 1)
+	if err != nil {
+		t.Fatalf("checkDrift failed: %v", err)
+	}
+
+	edited := &fakeMigration{name: "m1", hash: []byte("def")}
+	err = checkDrift(db, []Migration{nil, edited}, 1)
+	if err == nil {
+		t.Fatal("expected drift error, got nil")
+	}
+}
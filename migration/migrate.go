@@ -2,6 +2,9 @@ package migration
 
 import (
 	"encoding/json"
+	"errors"
+	"time"
+
 	liberr "github.com/konveyor/controller/pkg/error"
 	"github.com/konveyor/tackle2-hub/database"
 	"github.com/konveyor/tackle2-hub/model"
@@ -9,8 +12,17 @@ import (
 )
 
 //
-// Migrate the hub by applying all necessary Migrations.
-func Migrate(migrations []Migration) (err error) {
+// Latest requests that Migrate bring the schema to the most recently
+// defined version. Pass it as the target to preserve the historical
+// "apply everything pending" behavior.
+const Latest = -1
+
+//
+// Migrate the hub to the target version by applying pending Migrations
+// (upgrade) or reverting applied ones (downgrade). Each step is
+// performed in its own transaction along with the Version record so a
+// failure never leaves the two out of sync.
+func Migrate(migrations []Migration, target int) (err error) {
 	var db *gorm.DB
 
 	db, err = database.Open(false)
@@ -46,11 +58,51 @@ func Migrate(migrations []Migration) (err error) {
 	}
 
 	// Version is the index of the last successful migration,
-	// so we want to start iteration at the next index.
-	migrations = append([]Migration{nil}, migrations...)
-	for i := v.Version + 1; i < len(migrations); i++ {
+	// so migrations are 1-indexed.
+	all := append([]Migration{nil}, migrations...)
+	if target == Latest {
+		target = len(all) - 1
+	}
+	if target < 0 || target >= len(all) {
+		err = liberr.Wrap(errors.New("migration: target version out of range"))
+		return
+	}
+
+	db, err = database.Open(false)
+	if err != nil {
+		return
+	}
+	result = db.AutoMigrate(&MigrationHistory{})
+	if result.Error != nil {
+		err = liberr.Wrap(result.Error)
+		return
+	}
+	err = checkDrift(db, all, v.Version)
+	if err != nil {
+		return
+	}
+	err = database.Close(db)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case target > v.Version:
+		err = upgrade(all, v.Version, target)
+	case target < v.Version:
+		err = downgrade(all, v.Version, target)
+	}
+
+	return
+}
+
+//
+// upgrade applies pending migrations, in order, until version reaches target.
+func upgrade(migrations []Migration, version, target int) (err error) {
+	for i := version + 1; i <= target; i++ {
 		m := migrations[i]
 
+		var db *gorm.DB
 		db, err = database.Open(false)
 		if err != nil {
 			err = liberr.Wrap(err, "version", m.Name())
@@ -59,6 +111,7 @@ func Migrate(migrations []Migration) (err error) {
 
 		f := func(db *gorm.DB) (err error) {
 			log.Info("Running migration.", "version", m.Name())
+			started := time.Now()
 			err = m.Apply(db)
 			if err != nil {
 				return
@@ -67,11 +120,13 @@ func Migrate(migrations []Migration) (err error) {
 			if err != nil {
 				return
 			}
+			err = recordHistory(db, i, m, fingerprint(m), time.Since(started))
 			return
 		}
 		err = db.Transaction(f)
 		if err != nil {
 			err = liberr.Wrap(err, "version", m.Name())
+			_ = database.Close(db)
 			return
 		}
 
@@ -85,6 +140,61 @@ func Migrate(migrations []Migration) (err error) {
 	return
 }
 
+//
+// downgrade reverts applied migrations, in reverse order, until version
+// reaches target. Refuses to run if any migration in the range does not
+// implement Reverter.
+func downgrade(migrations []Migration, version, target int) (err error) {
+	for i := version; i > target; i-- {
+		if _, ok := migrations[i].(Reverter); !ok {
+			err = liberr.Wrap(
+				errors.New("migration: cannot revert, Revert() not implemented"),
+				"version", migrations[i].Name())
+			return
+		}
+	}
+
+	for i := version; i > target; i-- {
+		m := migrations[i].(Reverter)
+		name := migrations[i].Name()
+
+		var db *gorm.DB
+		db, err = database.Open(false)
+		if err != nil {
+			err = liberr.Wrap(err, "version", name)
+			return
+		}
+
+		f := func(db *gorm.DB) (err error) {
+			log.Info("Reverting migration.", "version", name)
+			err = m.Revert(db)
+			if err != nil {
+				return
+			}
+			err = deleteHistory(db, i)
+			if err != nil {
+				return
+			}
+			err = setVersion(db, i-1)
+			return
+		}
+		err = db.Transaction(f)
+		if err != nil {
+			err = liberr.Wrap(err, "version", name)
+			_ = database.Close(db)
+			return
+		}
+
+		err = database.Close(db)
+		if err != nil {
+			err = liberr.Wrap(err, "version", name)
+			return
+		}
+	}
+
+	return
+}
+
 //
 // Set the version record.
 func setVersion(db *gorm.DB, version int) (err error) {
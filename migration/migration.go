@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"github.com/konveyor/controller/pkg/logging"
+	"gorm.io/gorm"
+)
+
+var log = logging.WithName("migration")
+
+//
+// VersionKey is the Setting key used to store the schema Version.
+var VersionKey = "Version"
+
+//
+// Version record.
+type Version struct {
+	Version int `json:"version"`
+}
+
+//
+// Migration applies a schema/data change.
+type Migration interface {
+	// Apply the migration.
+	Apply(db *gorm.DB) error
+	// Name returns a short, stable, human-readable name.
+	Name() string
+	// Fingerprint returns a hash of the migration's content, used to
+	// detect drift between the compiled-in migration and the one
+	// recorded as applied in the migration_history table.
+	Fingerprint() []byte
+}
+
+//
+// Reverter is implemented by a Migration that supports being rolled
+// back. Not all migrations can be safely reverted; Migrate refuses to
+// downgrade through a version that does not implement it.
+type Reverter interface {
+	// Revert the migration.
+	Revert(db *gorm.DB) error
+}